@@ -44,47 +44,12 @@ func (s CheckResultStatus) String() string {
 	return "UNKOWN"
 }
 
-// NewCheckResult returns a new CheckResult
+// NewCheckResult evaluates a against the DefaultRegistry. Profiles that
+// need to disable checks or override their severity should call
+// DefaultRegistry.Disable/SeverityOverride to derive their own registry and
+// call Evaluate on that instead.
 func NewCheckResult(a *IssueAnalysis) *CheckResult {
-	result := &CheckResult{
-		Ready:  true,
-		Status: CheckStatusNone,
-	}
-
-	if a.Issue.InStatus(jira.IssueStatusObsolete) {
-		result.AddMessage("OBSOLETE")
-		return result
-	}
-
-	checks := []func(*IssueAnalysis){
-		result.checkAlongside,
-		result.checkVersion,
-		result.checkActivities,
-		result.checkDescription,
-		result.checkApprovals,
-		result.checkPlanningFlags,
-		result.checkDeliveryOwner,
-		result.checkQAContact,
-		result.checkAcceptanceCriteria,
-		result.checkPriority,
-		result.checkStarted,
-		result.checkStoryPoints,
-		result.checkImpediment,
-		result.checkInitiative,
-		result.checkIssueComponent,
-		result.checkComponent,
-		result.checkDone,
-		result.checkStartedStories,
-		result.checkLinkedEpic,
-		result.checkStatusComment,
-		result.checkDesign,
-	}
-
-	for _, f := range checks {
-		f(a)
-	}
-
-	return result
+	return DefaultRegistry.Evaluate(a)
 }
 
 // SetReady sets the ready status
@@ -109,189 +74,189 @@ func (r *CheckResult) AddMessage(message string) *CheckResult {
 	return r
 }
 
-func (r *CheckResult) checkAlongside(a *IssueAnalysis) {
-	for _, v := range a.Issue.Fields.FixVersions {
+func checkAlongside(ctx *CheckContext) {
+	for _, v := range ctx.Issue.Fields.FixVersions {
 		if strings.HasPrefix(v.Name, "Alongside") {
-			r.AddMessage("ALONGSIDE")
+			ctx.AddMessage("ALONGSIDE")
 			return
 		}
 	}
 }
 
 // checkVersion verifies that there is at least one version set
-func (r *CheckResult) checkVersion(a *IssueAnalysis) {
-	if len(a.Issue.Fields.FixVersions) == 0 {
-		r.SetReady(false).AddMessage("NOVERSION")
+func checkVersion(ctx *CheckContext) {
+	if len(ctx.Issue.Fields.FixVersions) == 0 {
+		ctx.SetReady(false).Flag("NOVERSION")
 	}
 
-	if len(a.Issue.Fields.FixVersions) > 1 {
-		r.AddMessage("MULTIVERSION")
+	if len(ctx.Issue.Fields.FixVersions) > 1 {
+		ctx.AddMessage("MULTIVERSION")
 	}
 }
 
 // checkActivities verifies that there is at least one story attached
-func (r *CheckResult) checkActivities(a *IssueAnalysis) {
-	if a.Issue.IsType(jira.IssueTypeEpic) && a.NumActivities == 0 {
-		r.SetReady(false).AddMessage("NOSTORIES")
+func checkActivities(ctx *CheckContext) {
+	if ctx.Issue.IsType(jira.IssueTypeEpic) && ctx.NumActivities == 0 {
+		ctx.SetReady(false).AddMessage("NOSTORIES")
 	}
 }
 
 // checkDescription verifies that the description is set
-func (r *CheckResult) checkDescription(a *IssueAnalysis) {
-	if a.Issue.Fields.Description == "" {
-		r.SetReady(false).AddMessage("NODESCRIPTION")
+func checkDescription(ctx *CheckContext) {
+	if ctx.Issue.Fields.Description == "" {
+		ctx.SetReady(false).AddMessage("NODESCRIPTION")
 	}
 }
 
 // checkApprovals verifies that the approvals are set
-func (r *CheckResult) checkApprovals(a *IssueAnalysis) {
-	if a.Issue.IsType(jira.IssueTypeEpic) && !a.Issue.Approved() {
-		r.SetReady(false).AddMessage("NOACKS")
+func checkApprovals(ctx *CheckContext) {
+	if ctx.Issue.IsType(jira.IssueTypeEpic) && !ctx.Issue.Approved() {
+		ctx.SetReady(false).AddMessage("NOACKS")
 	}
 }
 
 // checkDeliveryOwner verifies that an owner has been assigned
-func (r *CheckResult) checkDeliveryOwner(a *IssueAnalysis) {
-	if a.Issue.Owner == "" {
-		r.SetReady(false).SetStatus(CheckStatusRed).AddMessage("NODELIVERYOWNER")
+func checkDeliveryOwner(ctx *CheckContext) {
+	if ctx.Issue.Owner == "" {
+		ctx.SetReady(false).Flag("NODELIVERYOWNER")
 	}
 }
 
-func (r *CheckResult) checkPlanningFlags(a *IssueAnalysis) {
-	if a.Issue.Planning.NoQE {
-		r.AddMessage("NOQE")
+func checkPlanningFlags(ctx *CheckContext) {
+	if ctx.Issue.Planning.NoQE {
+		ctx.AddMessage("NOQE")
 	}
-	if a.Issue.Planning.NoFeature {
-		r.AddMessage("NOFEATURE")
+	if ctx.Issue.Planning.NoFeature {
+		ctx.AddMessage("NOFEATURE")
 	}
-	if a.Issue.Planning.NoDoc {
-		r.AddMessage("NODOC")
+	if ctx.Issue.Planning.NoDoc {
+		ctx.AddMessage("NODOC")
 	}
 }
 
 // checkQAContact verifies that a QA Contact has been assigned
-func (r *CheckResult) checkQAContact(a *IssueAnalysis) {
-	if a.Issue.Planning.NoQE {
-		if a.Issue.QAContact != "" {
-			r.SetReady(false).AddMessage("NOQEMISMATCH")
+func checkQAContact(ctx *CheckContext) {
+	if ctx.Issue.Planning.NoQE {
+		if ctx.Issue.QAContact != "" {
+			ctx.SetReady(false).AddMessage("NOQEMISMATCH")
 		}
-	} else if a.Issue.QAContact == "" {
-		r.SetReady(false).SetStatus(CheckStatusRed).AddMessage("NOQACONTACT")
+	} else if ctx.Issue.QAContact == "" {
+		ctx.SetReady(false).Flag("NOQACONTACT")
 	}
 }
 
 // checkAcceptanceCriteria verifies that the acceptance criteria are set
-func (r *CheckResult) checkAcceptanceCriteria(a *IssueAnalysis) {
-	if a.Issue.Acceptance == "" {
-		r.SetReady(false).SetStatus(CheckStatusRed).AddMessage("NOCRITERIA")
+func checkAcceptanceCriteria(ctx *CheckContext) {
+	if ctx.Issue.Acceptance == "" {
+		ctx.SetReady(false).Flag("NOCRITERIA")
 	}
 }
 
 // checkPriority veirfies that the priority is set
-func (r *CheckResult) checkPriority(a *IssueAnalysis) {
-	if !a.Issue.IsPrioritized() {
-		r.SetReady(false).SetStatus(CheckStatusRed).AddMessage("NOPRIORITY")
+func checkPriority(ctx *CheckContext) {
+	if !ctx.Issue.IsPrioritized() {
+		ctx.SetReady(false).Flag("NOPRIORITY")
 	}
 }
 
 // checkStarted verifies that the status is active or done
-func (r *CheckResult) checkStarted(a *IssueAnalysis) {
-	if !a.Issue.IsActive() && !a.Issue.InStatus(jira.IssueStatusDone) {
-		r.SetStatus(CheckStatusYellow).AddMessage("NOTSTARTED")
+func checkStarted(ctx *CheckContext) {
+	if !ctx.Issue.IsActive() && !ctx.Issue.InStatus(jira.IssueStatusDone) {
+		ctx.Flag("NOTSTARTED")
 	}
 }
 
 // checkStoryPoints verifies that all the stories have story points
-func (r *CheckResult) checkStoryPoints(a *IssueAnalysis) {
-	if a.PointsCompletion.Unknown > 0 {
-		r.AddMessage("NOSTORYPOINTS")
+func checkStoryPoints(ctx *CheckContext) {
+	if ctx.PointsCompletion.Unknown > 0 {
+		ctx.AddMessage("NOSTORYPOINTS")
 	}
 }
 
 // checkImpediment notifies if there is an impediment flagged
-func (r *CheckResult) checkImpediment(a *IssueAnalysis) {
-	if a.Issue.Impediment || a.Issue.LinkedIssues.AnyImpediment() {
-		r.SetStatus(CheckStatusRed).AddMessage("IMPEDIMENT")
+func checkImpediment(ctx *CheckContext) {
+	if ctx.Issue.Impediment || ctx.Issue.LinkedIssues.AnyImpediment() {
+		ctx.Flag("IMPEDIMENT")
 	}
 }
 
 // checkInitiative verifies that there is a linked initiative
-func (r *CheckResult) checkInitiative(a *IssueAnalysis) {
-	if a.Issue.IsType(jira.IssueTypeEpic) && a.Issue.ParentLink == "" {
-		r.SetReady(false).AddMessage("NOINITIATIVE")
+func checkInitiative(ctx *CheckContext) {
+	if ctx.Issue.IsType(jira.IssueTypeEpic) && ctx.Issue.ParentLink == "" {
+		ctx.SetReady(false).AddMessage("NOINITIATIVE")
 	}
 }
 
 // checkIssueComponent verifies that all the linked issues have at least a component
-func (r *CheckResult) checkIssueComponent(a *IssueAnalysis) {
-	if a.IssueNoComponent {
-		r.SetReady(false).AddMessage("ISSUENOCOMPONENT")
+func checkIssueComponent(ctx *CheckContext) {
+	if ctx.IssueNoComponent {
+		ctx.SetReady(false).AddMessage("ISSUENOCOMPONENT")
 	}
 }
 
 // checkComponent verifies that the relevant component is
-func (r *CheckResult) checkComponent(a *IssueAnalysis) {
-	if a.Component == nil {
+func checkComponent(ctx *CheckContext) {
+	if ctx.Component == nil {
 		return
 	}
 
 	missing := true
 
-	for _, c := range a.Issue.Fields.Components {
-		if c.Name == *a.Component {
+	for _, c := range ctx.Issue.Fields.Components {
+		if c.Name == *ctx.Component {
 			missing = false
 			break
 		}
 	}
 
 	if missing {
-		r.SetReady(false).SetStatus(CheckStatusYellow).AddMessage("NOCOMPONENT")
+		ctx.SetReady(false).Flag("NOCOMPONENT")
 	}
 }
 
 // checkDone verifies that all the conditions are met for the done status
-func (r *CheckResult) checkDone(a *IssueAnalysis) {
-	if !a.Issue.InStatus(jira.IssueStatusDone) {
+func checkDone(ctx *CheckContext) {
+	if !ctx.Issue.InStatus(jira.IssueStatusDone) {
 		return
 	}
 
-	if a.IssuesCompletion.Status != a.IssuesCompletion.Total ||
-		a.PointsCompletion.Status != a.PointsCompletion.Total {
-		r.SetStatus(CheckStatusRed).AddMessage("NOTDONE")
+	if ctx.IssuesCompletion.Status != ctx.IssuesCompletion.Total ||
+		ctx.PointsCompletion.Status != ctx.PointsCompletion.Total {
+		ctx.Flag("NOTDONE")
 	} else {
-		r.SetStatus(CheckStatusGreen)
+		ctx.SetStatus(CheckStatusGreen)
 	}
 }
 
 // checkStatusComment verifies the status comment
-func (r *CheckResult) checkStatusComment(a *IssueAnalysis) {
-	if a.CommentStatus == CheckStatusNone {
-		r.AddMessage("NOSTATUSCOMMENT")
+func checkStatusComment(ctx *CheckContext) {
+	if ctx.CommentStatus == CheckStatusNone {
+		ctx.AddMessage("NOSTATUSCOMMENT")
 	} else {
-		r.SetStatus(a.CommentStatus)
+		ctx.SetStatus(ctx.CommentStatus)
 	}
 }
 
-func (r *CheckResult) checkLinkedEpic(a *IssueAnalysis) {
-	if !a.Issue.IsType(jira.IssueTypeStory) {
+func checkLinkedEpic(ctx *CheckContext) {
+	if !ctx.Issue.IsType(jira.IssueTypeStory) {
 		return
 	}
 
-	if a.Issue.Fields.Epic == nil || a.Issue.Fields.Epic.Key == "" {
-		r.SetReady(false).AddMessage("NOEPIC")
+	if ctx.Issue.Fields.Epic == nil || ctx.Issue.Fields.Epic.Key == "" {
+		ctx.SetReady(false).AddMessage("NOEPIC")
 	}
 }
 
-func (r *CheckResult) checkStartedStories(a *IssueAnalysis) {
-	if !a.Issue.IsType(jira.IssueTypeEpic) || !a.Issue.IsActive() {
+func checkStartedStories(ctx *CheckContext) {
+	if !ctx.Issue.IsType(jira.IssueTypeEpic) || !ctx.Issue.IsActive() {
 		return
 	}
 
-	linkedIssues := a.Issue.LinkedIssues
+	linkedIssues := ctx.Issue.LinkedIssues
 
-	if a.Component != nil {
+	if ctx.Component != nil {
 		linkedIssues = linkedIssues.FilterByFunction(func(i *jira.Issue) bool {
-			return i.HasComponent(*a.Component)
+			return i.HasComponent(*ctx.Component)
 		})
 	}
 
@@ -300,18 +265,12 @@ func (r *CheckResult) checkStartedStories(a *IssueAnalysis) {
 	})
 
 	if len(activeIssues) == 0 {
-		r.SetStatus(CheckStatusRed).AddMessage("NOACTIVESTORIES")
+		ctx.Flag("NOACTIVESTORIES")
 	}
 }
 
-func (r *CheckResult) checkVersions(a *IssueAnalysis) {
-	if !a.Issue.IsType(jira.IssueTypeEpic) {
-
-	}
-}
-
-func (r *CheckResult) checkDesign(a *IssueAnalysis) {
-	if !a.Issue.Planning.NoFeature && a.Issue.Design == "" {
-		r.SetReady(false).AddMessage("NODESIGN")
+func checkDesign(ctx *CheckContext) {
+	if !ctx.Issue.Planning.NoFeature && ctx.Issue.Design == "" {
+		ctx.SetReady(false).AddMessage("NODESIGN")
 	}
 }
@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+)
+
+// CustomCheckConfig is one entry of a profile's checks.custom config: a CEL
+// expression evaluated against the analyzed issue, registered with its own
+// ID/severity/description alongside the built-in checks.
+//
+// Only CEL is supported here, not JQL: JQL is Jira's server-side search
+// syntax and has no meaning against an already-fetched IssueAnalysis. A
+// profile that wants JQL-level filtering belongs in profile.JQL, not here.
+type CustomCheckConfig struct {
+	ID          string
+	Severity    string
+	Description string
+	Expr        string
+}
+
+// customCheckEnv declares the identifiers a checks.custom CEL expression
+// can reference: the same scalar fields a built-in CheckFunc reaches
+// through its *CheckContext, projected into CEL-compatible types so a
+// profile can express things like "flag if any linked issue is impeded"
+// (anyLinkedImpediment) without needing a Go check of its own.
+func customCheckEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("ready", cel.BoolType),
+		cel.Variable("component", cel.StringType),
+		cel.Variable("hasComponent", cel.BoolType),
+		cel.Variable("pointsTotal", cel.IntType),
+		cel.Variable("pointsUnknown", cel.IntType),
+		cel.Variable("issuesTotal", cel.IntType),
+		cel.Variable("issuesStatus", cel.IntType),
+		cel.Variable("numActivities", cel.IntType),
+		cel.Variable("issueNoComponent", cel.BoolType),
+		cel.Variable("linkedIssuesTotal", cel.IntType),
+		cel.Variable("anyLinkedImpediment", cel.BoolType),
+	)
+}
+
+// CompileCustomCheck compiles cfg.Expr and returns the predicate a
+// CheckContext must satisfy for the check to flag, ready to pass to
+// CheckRegistry.RegisterCustom.
+func CompileCustomCheck(cfg CustomCheckConfig) (func(*CheckContext) bool, error) {
+	env, err := customCheckEnv()
+	if err != nil {
+		return nil, fmt.Errorf("analysis: building CEL environment: %w", err)
+	}
+
+	ast, issues := env.Compile(cfg.Expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("analysis: compiling custom check %q: %w", cfg.ID, issues.Err())
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("analysis: preparing custom check %q: %w", cfg.ID, err)
+	}
+
+	return func(ctx *CheckContext) bool {
+		component, hasComponent := "", false
+		if ctx.Component != nil {
+			component, hasComponent = *ctx.Component, true
+		}
+
+		out, _, err := program.Eval(map[string]interface{}{
+			"ready":               ctx.result.Ready,
+			"component":           component,
+			"hasComponent":        hasComponent,
+			"pointsTotal":         ctx.PointsCompletion.Total,
+			"pointsUnknown":       ctx.PointsCompletion.Unknown,
+			"issuesTotal":         ctx.IssuesCompletion.Total,
+			"issuesStatus":        ctx.IssuesCompletion.Status,
+			"numActivities":       ctx.NumActivities,
+			"issueNoComponent":    ctx.IssueNoComponent,
+			"linkedIssuesTotal":   len(ctx.Issue.LinkedIssues),
+			"anyLinkedImpediment": ctx.Issue.LinkedIssues.AnyImpediment(),
+		})
+		if err != nil {
+			return false
+		}
+
+		matched, ok := out.Value().(bool)
+		return ok && matched
+	}, nil
+}
+
+// RegisterCustomChecks compiles and registers every entry of configs
+// against registry, as driven by a profile's checks.custom config.
+func RegisterCustomChecks(registry *CheckRegistry, configs []CustomCheckConfig) error {
+	for _, cfg := range configs {
+		severity, err := ParseCheckSeverity(cfg.Severity)
+		if err != nil {
+			return err
+		}
+
+		eval, err := CompileCustomCheck(cfg)
+		if err != nil {
+			return err
+		}
+
+		registry.RegisterCustom(cfg.ID, severity, cfg.Description, eval)
+	}
+
+	return nil
+}
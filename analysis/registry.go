@@ -0,0 +1,230 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	"io.bytenix.com/jiracsv/jira"
+)
+
+// CheckFunc evaluates a single check against ctx, using ctx.SetReady,
+// ctx.AddMessage and ctx.Flag to record its outcome.
+type CheckFunc func(ctx *CheckContext)
+
+// CheckDefinition is one registered check: a stable ID a profile's
+// checks.disable/checks.severity_override config can refer to, the
+// severity Flag applies by default, a short description for -list-checks,
+// and the evaluation function itself.
+type CheckDefinition struct {
+	ID          string
+	Severity    CheckResultStatus
+	Description string
+	Fn          CheckFunc
+}
+
+// CheckContext is passed to each CheckFunc. It gives custom checks uniform
+// access to the analyzed issue's fields (PointsCompletion, LinkedIssues,
+// ...) through the embedded *IssueAnalysis, alongside the means to record
+// the check's outcome against the in-progress CheckResult.
+type CheckContext struct {
+	*IssueAnalysis
+
+	result   *CheckResult
+	severity CheckResultStatus
+}
+
+// SetReady marks the issue not-ready. Once cleared by any check, it stays
+// cleared for the rest of the evaluation.
+func (ctx *CheckContext) SetReady(ready bool) *CheckContext {
+	ctx.result.SetReady(ready)
+	return ctx
+}
+
+// SetStatus raises the result's status to status, if status is more severe
+// than the current one. Checks whose severity is derived from data rather
+// than registry config (e.g. checkStatusComment) call this directly;
+// everything else should prefer Flag.
+func (ctx *CheckContext) SetStatus(status CheckResultStatus) *CheckContext {
+	ctx.result.SetStatus(status)
+	return ctx
+}
+
+// AddMessage appends message without affecting readiness or status.
+func (ctx *CheckContext) AddMessage(message string) *CheckContext {
+	ctx.result.AddMessage(message)
+	return ctx
+}
+
+// Flag appends message and raises the result's status to this check's
+// severity, as registered in the CheckRegistry or overridden by the
+// profile's checks.severity_override config.
+func (ctx *CheckContext) Flag(message string) *CheckContext {
+	ctx.result.SetStatus(ctx.severity).AddMessage(message)
+	return ctx
+}
+
+// CheckRegistry holds an ordered set of checks and the per-profile
+// disable/severity_override state layered on top of them. The zero value
+// is not usable; construct one with NewCheckRegistry.
+type CheckRegistry struct {
+	checks    []CheckDefinition
+	disabled  map[string]bool
+	overrides map[string]CheckResultStatus
+}
+
+// NewCheckRegistry returns a registry populated with jiracsv's built-in
+// checks, in evaluation order.
+func NewCheckRegistry() *CheckRegistry {
+	r := &CheckRegistry{
+		disabled:  map[string]bool{},
+		overrides: map[string]CheckResultStatus{},
+	}
+
+	r.Register(CheckDefinition{"alongside", CheckStatusNone, "Flags an Alongside fix version", checkAlongside})
+	r.Register(CheckDefinition{"no-version", CheckStatusNone, "Requires at least one fix version", checkVersion})
+	r.Register(CheckDefinition{"no-stories", CheckStatusNone, "Requires at least one linked story on an epic", checkActivities})
+	r.Register(CheckDefinition{"no-description", CheckStatusNone, "Requires a description", checkDescription})
+	r.Register(CheckDefinition{"no-acks", CheckStatusNone, "Requires epic approvals", checkApprovals})
+	r.Register(CheckDefinition{"no-delivery-owner", CheckStatusRed, "Requires a delivery owner", checkDeliveryOwner})
+	r.Register(CheckDefinition{"planning-flags", CheckStatusNone, "Surfaces NoQE/NoFeature/NoDoc planning flags", checkPlanningFlags})
+	r.Register(CheckDefinition{"no-qa-contact", CheckStatusRed, "Requires a QA contact unless NoQE is set", checkQAContact})
+	r.Register(CheckDefinition{"no-criteria", CheckStatusRed, "Requires acceptance criteria", checkAcceptanceCriteria})
+	r.Register(CheckDefinition{"no-priority", CheckStatusRed, "Requires a priority", checkPriority})
+	r.Register(CheckDefinition{"not-started", CheckStatusYellow, "Flags issues that are neither active nor done", checkStarted})
+	r.Register(CheckDefinition{"no-story-points", CheckStatusNone, "Flags linked stories missing story points", checkStoryPoints})
+	r.Register(CheckDefinition{"impediment", CheckStatusRed, "Flags an impediment on the issue or a linked issue", checkImpediment})
+	r.Register(CheckDefinition{"no-initiative", CheckStatusNone, "Requires an epic to link a parent initiative", checkInitiative})
+	r.Register(CheckDefinition{"issue-no-component", CheckStatusNone, "Requires every linked issue to carry a component", checkIssueComponent})
+	r.Register(CheckDefinition{"no-component", CheckStatusYellow, "Requires the issue to carry the profile's component", checkComponent})
+	r.Register(CheckDefinition{"not-done", CheckStatusRed, "Flags a Done issue whose completion isn't actually 100%", checkDone})
+	r.Register(CheckDefinition{"no-active-stories", CheckStatusRed, "Requires an active epic to have an active or done story", checkStartedStories})
+	r.Register(CheckDefinition{"no-epic", CheckStatusNone, "Requires a story to link a parent epic", checkLinkedEpic})
+	r.Register(CheckDefinition{"no-status-comment", CheckStatusNone, "Requires a recognised status comment", checkStatusComment})
+	r.Register(CheckDefinition{"no-design", CheckStatusNone, "Requires a design doc unless NoFeature is set", checkDesign})
+
+	return r
+}
+
+// Register adds def to the registry's evaluation order. Re-registering an
+// existing ID replaces it in place, so a profile can redefine a built-in
+// check's Fn without changing where it runs.
+func (r *CheckRegistry) Register(def CheckDefinition) {
+	for i, existing := range r.checks {
+		if existing.ID == def.ID {
+			r.checks[i] = def
+			return
+		}
+	}
+
+	r.checks = append(r.checks, def)
+}
+
+// RegisterCustom adds a profile-defined check (checks.custom config) to the
+// registry. eval is whatever evaluates the profile's predicate against ctx
+// (CompileCustomCheck builds one from a CEL expression); the check flags
+// id's default message when eval returns true.
+func (r *CheckRegistry) RegisterCustom(id string, severity CheckResultStatus, description string, eval func(*CheckContext) bool) {
+	r.Register(CheckDefinition{
+		ID:          id,
+		Severity:    severity,
+		Description: description,
+		Fn: func(ctx *CheckContext) {
+			if eval(ctx) {
+				ctx.Flag(strings.ToUpper(strings.ReplaceAll(id, "-", "")))
+			}
+		},
+	})
+}
+
+// Disable derives a copy of r that skips the given check IDs during
+// Evaluate, as set by a profile's checks.disable config.
+func (r *CheckRegistry) Disable(ids ...string) *CheckRegistry {
+	clone := r.clone()
+
+	for _, id := range ids {
+		clone.disabled[id] = true
+	}
+
+	return clone
+}
+
+// SeverityOverride derives a copy of r where id raises the result to
+// severity instead of its registered default, as set by a profile's
+// checks.severity_override config.
+func (r *CheckRegistry) SeverityOverride(id string, severity CheckResultStatus) *CheckRegistry {
+	clone := r.clone()
+	clone.overrides[id] = severity
+	return clone
+}
+
+func (r *CheckRegistry) clone() *CheckRegistry {
+	disabled := make(map[string]bool, len(r.disabled))
+	for k, v := range r.disabled {
+		disabled[k] = v
+	}
+
+	overrides := make(map[string]CheckResultStatus, len(r.overrides))
+	for k, v := range r.overrides {
+		overrides[k] = v
+	}
+
+	checks := append([]CheckDefinition(nil), r.checks...)
+
+	return &CheckRegistry{checks: checks, disabled: disabled, overrides: overrides}
+}
+
+// List returns the registry's checks in evaluation order, for -list-checks.
+func (r *CheckRegistry) List() []CheckDefinition {
+	out := make([]CheckDefinition, len(r.checks))
+	copy(out, r.checks)
+	return out
+}
+
+// Evaluate runs every enabled check against a and returns the combined
+// CheckResult.
+func (r *CheckRegistry) Evaluate(a *IssueAnalysis) *CheckResult {
+	result := &CheckResult{Ready: true, Status: CheckStatusNone}
+
+	if a.Issue.InStatus(jira.IssueStatusObsolete) {
+		result.AddMessage("OBSOLETE")
+		return result
+	}
+
+	for _, def := range r.checks {
+		if r.disabled[def.ID] {
+			continue
+		}
+
+		severity := def.Severity
+		if override, ok := r.overrides[def.ID]; ok {
+			severity = override
+		}
+
+		def.Fn(&CheckContext{IssueAnalysis: a, result: result, severity: severity})
+	}
+
+	return result
+}
+
+// ParseCheckSeverity parses a config value such as "red" into the matching
+// CheckResultStatus, for checks.severity_override.
+func ParseCheckSeverity(s string) (CheckResultStatus, error) {
+	switch strings.ToLower(s) {
+	case "none":
+		return CheckStatusNone, nil
+	case "green":
+		return CheckStatusGreen, nil
+	case "yellow":
+		return CheckStatusYellow, nil
+	case "red":
+		return CheckStatusRed, nil
+	default:
+		return CheckStatusNone, fmt.Errorf("analysis: unknown check severity %q", s)
+	}
+}
+
+// DefaultRegistry is the built-in set of checks NewCheckResult evaluates.
+// Profiles with checks config derive their own registry from it via
+// Disable/SeverityOverride/RegisterCustom rather than mutating it, so that
+// one profile's config can't affect another's analysis.
+var DefaultRegistry = NewCheckRegistry()
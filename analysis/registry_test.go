@@ -0,0 +1,76 @@
+package analysis
+
+import "testing"
+
+func hasCheck(checks []CheckDefinition, id string) bool {
+	for _, c := range checks {
+		if c.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestRegisterReplacesInPlace(t *testing.T) {
+	r := &CheckRegistry{disabled: map[string]bool{}, overrides: map[string]CheckResultStatus{}}
+
+	r.Register(CheckDefinition{ID: "x", Description: "first"})
+	r.Register(CheckDefinition{ID: "x", Description: "second"})
+
+	checks := r.List()
+	if len(checks) != 1 {
+		t.Fatalf("List() = %d checks, want 1", len(checks))
+	}
+	if checks[0].Description != "second" {
+		t.Fatalf("List()[0].Description = %q, want %q", checks[0].Description, "second")
+	}
+}
+
+func TestCheckRegistryCloneIsolation(t *testing.T) {
+	base := NewCheckRegistry()
+
+	a := base.Disable("alongside")
+	b := base.Disable("no-version")
+
+	a.Register(CheckDefinition{ID: "only-a"})
+	b.Register(CheckDefinition{ID: "only-b"})
+
+	if !hasCheck(a.List(), "only-a") {
+		t.Error("a.List() missing only-a")
+	}
+	if hasCheck(a.List(), "only-b") {
+		t.Error("a.List() leaked only-b from b's clone")
+	}
+
+	if !hasCheck(b.List(), "only-b") {
+		t.Error("b.List() missing only-b")
+	}
+	if hasCheck(b.List(), "only-a") {
+		t.Error("b.List() leaked only-a from a's clone")
+	}
+
+	if hasCheck(base.List(), "only-a") || hasCheck(base.List(), "only-b") {
+		t.Error("base.List() leaked a check registered on a derived clone")
+	}
+}
+
+func TestCheckRegistryDisableAndSeverityOverride(t *testing.T) {
+	base := NewCheckRegistry()
+
+	disabled := base.Disable("no-version")
+	if !disabled.disabled["no-version"] {
+		t.Error("Disable() did not mark the check disabled on the clone")
+	}
+	if base.disabled["no-version"] {
+		t.Error("Disable() mutated the base registry's disabled set")
+	}
+
+	overridden := base.SeverityOverride("no-version", CheckStatusRed)
+	if overridden.overrides["no-version"] != CheckStatusRed {
+		t.Error("SeverityOverride() did not record the override on the clone")
+	}
+	if _, ok := base.overrides["no-version"]; ok {
+		t.Error("SeverityOverride() mutated the base registry's overrides")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"log"
+
+	"io.bytenix.com/jiracsv/analysis"
+	"io.bytenix.com/jiracsv/fsserver"
+	"io.bytenix.com/jiracsv/jira"
+)
+
+// componentWalker adapts a *ComponentsCollection, already populated for the
+// current profile, to fsserver.IssueWalker.
+type componentWalker struct {
+	collection *ComponentsCollection
+}
+
+func (w *componentWalker) Components() []string {
+	names := make([]string, 0, len(w.collection.Items)+1)
+
+	for _, c := range w.collection.Items {
+		names = append(names, c.Name)
+	}
+
+	return append(names, "[UNASSIGNED]")
+}
+
+func (w *componentWalker) Issues(component *string) []*jira.Issue {
+	if component == nil {
+		return w.collection.Orphans
+	}
+
+	for _, c := range w.collection.Items {
+		if c.Name == *component {
+			return c.Issues
+		}
+	}
+
+	if *component == "[UNASSIGNED]" {
+		return w.collection.Orphans
+	}
+
+	return nil
+}
+
+// fsserve runs the -fs command mode: instead of writing CSV, it mounts the
+// already-fetched profile as a 9P filesystem and blocks until the listener
+// exits. registry should be the same profile-derived CheckRegistry used for
+// every other output format, so -fs mode reports identical check results.
+func fsserve(profile string, collection *ComponentsCollection, client *jira.Client, registry *analysis.CheckRegistry) error {
+	srv := fsserver.NewServer(profile, &componentWalker{collection: collection}, client, registry, fsserver.Config{
+		Addr:     commandFlags.FsAddr,
+		CacheTTL: commandFlags.FsCacheTTL,
+	})
+
+	log.Printf("fsserve: listening on %s", commandFlags.FsAddr)
+
+	return srv.ListenAndServe()
+}
@@ -0,0 +1,57 @@
+package fsserver
+
+import (
+	"sync"
+	"time"
+
+	"io.bytenix.com/jiracsv/analysis"
+)
+
+// cache holds recently computed analysis/check pairs keyed by issue key,
+// reused until ttl elapses or the entry is explicitly invalidated by a
+// write.
+type cache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	analysis *analysis.IssueAnalysis
+	result   *analysis.CheckResult
+	expires  time.Time
+}
+
+func newCache(ttl time.Duration) *cache {
+	return &cache{ttl: ttl, entries: make(map[string]cacheEntry)}
+}
+
+// get returns the cached pair for key, recomputing it with compute if the
+// entry is missing, expired, or caching is disabled (ttl == 0).
+func (c *cache) get(key string, compute func() (*analysis.IssueAnalysis, *analysis.CheckResult)) (*analysis.IssueAnalysis, *analysis.CheckResult) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && c.ttl > 0 && time.Now().Before(e.expires) {
+		c.mu.Unlock()
+		return e.analysis, e.result
+	}
+	c.mu.Unlock()
+
+	a, r := compute()
+
+	if c.ttl > 0 {
+		c.mu.Lock()
+		c.entries[key] = cacheEntry{analysis: a, result: r, expires: time.Now().Add(c.ttl)}
+		c.mu.Unlock()
+	}
+
+	return a, r
+}
+
+// invalidate drops any cached entry for key, forcing the next read to
+// recompute it from the in-memory jira.Issue (which is not refetched).
+func (c *cache) invalidate(key string) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
@@ -0,0 +1,103 @@
+// Package fsserver exposes analyzed Jira issues through a 9P filesystem, so
+// that a profile can be browsed and scripted with standard shell tools
+// instead of read from a one-shot CSV dump.
+//
+// The exported tree follows the same shape jirafs uses for its raw Jira
+// view: /<profile>/<component>/<issue-key>/, with one synthetic file per
+// field of interest. Writable files push their new value back to Jira
+// through the jira.Client passed to NewServer.
+package fsserver
+
+import (
+	"time"
+
+	"aqwari.net/net/styx"
+
+	"io.bytenix.com/jiracsv/analysis"
+	"io.bytenix.com/jiracsv/jira"
+)
+
+// IssueLister returns the issues to expose under a single component,
+// analogous to jirafs's jiraLister. component is nil for the synthetic
+// "[UNASSIGNED]" grouping.
+type IssueLister interface {
+	Issues(component *string) []*jira.Issue
+}
+
+// IssueWalker enumerates the components that make up a profile, analogous
+// to jirafs's jiraWalker. Synthetic views (e.g. a /checks/red/ grouping)
+// are implemented by wrapping an IssueWalker rather than modifying it.
+type IssueWalker interface {
+	Components() []string
+	IssueLister
+}
+
+// Config controls how a Server caches analysis and exposes writes.
+type Config struct {
+	// Addr is the address to listen on, e.g. "tcp!0.0.0.0!5640" or a unix
+	// socket path. Empty means styx's default.
+	Addr string
+
+	// CacheTTL is how long an IssueAnalysis/CheckResult pair is reused
+	// before being recomputed from the jira.Issue the Walker holds; the
+	// issue itself is not refetched from Jira. Zero disables caching and
+	// recomputes on every read.
+	CacheTTL time.Duration
+}
+
+// Server serves a single profile's analyzed issues as a 9P filesystem.
+type Server struct {
+	Profile  string
+	Walker   IssueWalker
+	Client   *jira.Client
+	Registry *analysis.CheckRegistry
+
+	cfg    Config
+	cache  *cache
+	checks map[string]*ChecksView
+}
+
+// NewServer returns a Server ready to be passed to styx.Serve. profile is
+// used only as the top-level directory name. registry should be the same
+// profile-derived CheckRegistry (checks.disable/checks.severity_override
+// applied) used by the CSV/output renderers, so -fs mode reports the same
+// check results as every other output format.
+//
+// Alongside the normal /<component>/<issue-key>/ tree, NewServer also
+// mounts /checks/red/, /checks/yellow/ and /checks/green/, each flattening
+// every component down to the issues currently flagged that status.
+func NewServer(profile string, walker IssueWalker, client *jira.Client, registry *analysis.CheckRegistry, cfg Config) *Server {
+	srv := &Server{
+		Profile:  profile,
+		Walker:   walker,
+		Client:   client,
+		Registry: registry,
+		cfg:      cfg,
+		cache:    newCache(cfg.CacheTTL),
+	}
+
+	srv.checks = map[string]*ChecksView{
+		"red":    {Walker: walker, Server: srv, Want: analysis.CheckStatusRed},
+		"yellow": {Walker: walker, Server: srv, Want: analysis.CheckStatusYellow},
+		"green":  {Walker: walker, Server: srv, Want: analysis.CheckStatusGreen},
+	}
+
+	return srv
+}
+
+// ListenAndServe starts the 9P listener and blocks until it exits.
+func (srv *Server) ListenAndServe() error {
+	s := &styx.Server{
+		Addr:    srv.cfg.Addr,
+		Handler: srv,
+	}
+
+	return s.ListenAndServe()
+}
+
+func (srv *Server) analysisFor(component *string, issue *jira.Issue) (*analysis.IssueAnalysis, *analysis.CheckResult) {
+	return srv.cache.get(issue.Key, func() (*analysis.IssueAnalysis, *analysis.CheckResult) {
+		a := analysis.NewIssueAnalysis(issue, component)
+		return a, srv.Registry.Evaluate(a)
+	})
+}
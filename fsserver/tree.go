@@ -0,0 +1,394 @@
+package fsserver
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"aqwari.net/net/styx"
+
+	"io.bytenix.com/jiracsv/analysis"
+	"io.bytenix.com/jiracsv/jira"
+)
+
+// issueFiles lists the synthetic files present inside each issue directory,
+// in the order they should be returned by a directory read.
+var issueFiles = []string{
+	"summary",
+	"status",
+	"owner",
+	"qa-contact",
+	"check-status",
+	"check-messages",
+	"ready",
+	"points-completion",
+	"description",
+	"acceptance",
+	"comment",
+}
+
+// writableFiles are the issue files that, when written to, push their new
+// content back to Jira instead of being read-only snapshots of it.
+var writableFiles = map[string]bool{
+	"owner":       true,
+	"qa-contact":  true,
+	"description": true,
+	"acceptance":  true,
+	"comment":     true,
+}
+
+// Serve9P implements styx.Service, dispatching walk/open/read/write requests
+// against the /<profile>/<component>/<issue-key>/<file> tree.
+func (srv *Server) Serve9P(s *styx.Session) {
+	for s.Next() {
+		switch t := s.Request().(type) {
+		case styx.Twalk:
+			t.Rwalk(srv.stat(t.Path()))
+		case styx.Topen:
+			t.Ropen(srv.open(t.Path(), t.Flag))
+		case styx.Tstat:
+			fi, err := srv.stat(t.Path())
+			t.Rstat(fi, err)
+		}
+	}
+}
+
+// stat resolves a slash-separated 9P path to an os.FileInfo describing the
+// profile root, a component directory, an issue directory, or a file. Paths
+// rooted at checksDir are delegated to statChecks instead.
+func (srv *Server) stat(p string) (os.FileInfo, error) {
+	parts := splitPath(p)
+
+	if len(parts) > 0 && parts[0] == checksDir {
+		return srv.statChecks(parts[1:])
+	}
+
+	switch len(parts) {
+	case 0:
+		return dirInfo(srv.Profile), nil
+	case 1:
+		for _, c := range srv.Walker.Components() {
+			if c == parts[0] {
+				return dirInfo(c), nil
+			}
+		}
+		return nil, os.ErrNotExist
+	case 2:
+		issue := srv.findIssue(parts[0], parts[1])
+		if issue == nil {
+			return nil, os.ErrNotExist
+		}
+		return dirInfo(issue.Key), nil
+	case 3:
+		issue := srv.findIssue(parts[0], parts[1])
+		if issue == nil {
+			return nil, os.ErrNotExist
+		}
+		content, err := srv.readFile(parts[0], issue, parts[2])
+		if err != nil {
+			return nil, err
+		}
+		return fileInfo(parts[2], len(content)), nil
+	default:
+		return nil, os.ErrNotExist
+	}
+}
+
+// statChecks resolves a path below checksDir: parts[0] is the status name
+// ("red", "yellow", "green"), parts[1] an issue key, parts[2] a file.
+func (srv *Server) statChecks(parts []string) (os.FileInfo, error) {
+	switch len(parts) {
+	case 0:
+		return dirInfo(checksDir), nil
+	case 1:
+		if _, ok := srv.checks[parts[0]]; !ok {
+			return nil, os.ErrNotExist
+		}
+		return dirInfo(parts[0]), nil
+	case 2:
+		view, ok := srv.checks[parts[0]]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		issue, _ := view.find(parts[1])
+		if issue == nil {
+			return nil, os.ErrNotExist
+		}
+		return dirInfo(issue.Key), nil
+	case 3:
+		view, ok := srv.checks[parts[0]]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		issue, component := view.find(parts[1])
+		if issue == nil {
+			return nil, os.ErrNotExist
+		}
+		content, err := srv.readFile(component, issue, parts[2])
+		if err != nil {
+			return nil, err
+		}
+		return fileInfo(parts[2], len(content)), nil
+	default:
+		return nil, os.ErrNotExist
+	}
+}
+
+// open returns a ReadWriteCloser backing a single issue file, or a read-only
+// listing of a directory's entries. Reads of a file are served from the
+// cached analysis; writes are buffered until Close, at which point they're
+// pushed to Jira and the cache entry is invalidated.
+func (srv *Server) open(p string, flag int) (interface{ io.ReadWriteCloser }, error) {
+	parts := splitPath(p)
+
+	if len(parts) > 0 && parts[0] == checksDir {
+		return srv.openChecks(parts[1:])
+	}
+
+	switch len(parts) {
+	case 0:
+		return newDirReader(append(append([]string(nil), srv.Walker.Components()...), checksDir)), nil
+	case 1:
+		component := parts[0]
+
+		found := false
+		for _, c := range srv.Walker.Components() {
+			if c == component {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, os.ErrNotExist
+		}
+
+		var c *string
+		if component != unassignedDir {
+			c = &component
+		}
+
+		issues := srv.Walker.Issues(c)
+		names := make([]string, len(issues))
+		for i, issue := range issues {
+			names[i] = issue.Key
+		}
+
+		return newDirReader(names), nil
+	case 2:
+		if srv.findIssue(parts[0], parts[1]) == nil {
+			return nil, os.ErrNotExist
+		}
+
+		return newDirReader(issueFiles), nil
+	case 3:
+		component, key, name := parts[0], parts[1], parts[2]
+
+		issue := srv.findIssue(component, key)
+		if issue == nil {
+			return nil, os.ErrNotExist
+		}
+
+		content, err := srv.readFile(component, issue, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return &issueFile{
+			srv:       srv,
+			component: component,
+			issue:     issue,
+			name:      name,
+			content:   content,
+		}, nil
+	default:
+		return nil, os.ErrInvalid
+	}
+}
+
+// openChecks mirrors open for paths below checksDir: parts[0] is the
+// status name, parts[1] an issue key, parts[2] a file.
+func (srv *Server) openChecks(parts []string) (interface{ io.ReadWriteCloser }, error) {
+	switch len(parts) {
+	case 0:
+		names := make([]string, 0, len(srv.checks))
+		for name := range srv.checks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return newDirReader(names), nil
+	case 1:
+		view, ok := srv.checks[parts[0]]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+
+		issues := view.Issues(nil)
+		names := make([]string, len(issues))
+		for i, issue := range issues {
+			names[i] = issue.Key
+		}
+
+		return newDirReader(names), nil
+	case 2:
+		view, ok := srv.checks[parts[0]]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		if issue, _ := view.find(parts[1]); issue == nil {
+			return nil, os.ErrNotExist
+		}
+
+		return newDirReader(issueFiles), nil
+	case 3:
+		view, ok := srv.checks[parts[0]]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+
+		issue, component := view.find(parts[1])
+		if issue == nil {
+			return nil, os.ErrNotExist
+		}
+
+		name := parts[2]
+
+		content, err := srv.readFile(component, issue, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return &issueFile{
+			srv:       srv,
+			component: component,
+			issue:     issue,
+			name:      name,
+			content:   content,
+		}, nil
+	default:
+		return nil, os.ErrInvalid
+	}
+}
+
+// dirReader serves a read-only newline-separated listing of a directory's
+// entries, so a 9P client can `ls` into the profile root, a component
+// directory, or an issue directory instead of needing to already know an
+// exact file path.
+type dirReader struct {
+	content []byte
+	pos     int
+}
+
+func newDirReader(names []string) *dirReader {
+	return &dirReader{content: []byte(strings.Join(names, "\n") + "\n")}
+}
+
+func (d *dirReader) Read(p []byte) (int, error) {
+	if d.pos >= len(d.content) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, d.content[d.pos:])
+	d.pos += n
+
+	return n, nil
+}
+
+func (d *dirReader) Write(p []byte) (int, error) { return 0, os.ErrPermission }
+func (d *dirReader) Close() error                { return nil }
+
+func (srv *Server) findIssue(component, key string) *jira.Issue {
+	var c *string
+	if component != unassignedDir {
+		c = &component
+	}
+
+	for _, i := range srv.Walker.Issues(c) {
+		if i.Key == key {
+			return i
+		}
+	}
+
+	return nil
+}
+
+// readFile renders a single synthetic file's content for an issue.
+func (srv *Server) readFile(component string, issue *jira.Issue, name string) ([]byte, error) {
+	var c *string
+	if component != unassignedDir {
+		c = &component
+	}
+
+	a, r := srv.analysisFor(c, issue)
+
+	switch name {
+	case "summary":
+		return []byte(issue.Fields.Summary + "\n"), nil
+	case "status":
+		return []byte(issue.Fields.Status.Name + "\n"), nil
+	case "owner":
+		return []byte(issue.Owner + "\n"), nil
+	case "qa-contact":
+		return []byte(issue.QAContact + "\n"), nil
+	case "check-status":
+		return []byte(r.Status.String() + "\n"), nil
+	case "check-messages":
+		return []byte(strings.Join(r.Messages, "\n") + "\n"), nil
+	case "ready":
+		return []byte(strconv.FormatBool(r.Ready) + "\n"), nil
+	case "points-completion":
+		return []byte(fmt.Sprintf("%d/%d\n", a.PointsCompletion.Status, a.PointsCompletion.Total)), nil
+	case "description":
+		return []byte(issue.Fields.Description), nil
+	case "acceptance":
+		return []byte(issue.Acceptance), nil
+	case "comment":
+		return nil, nil
+	default:
+		return nil, os.ErrNotExist
+	}
+}
+
+const unassignedDir = "[UNASSIGNED]"
+
+// checksDir is the top-level synthetic directory mounting the per-status
+// ChecksView trees built in NewServer, e.g. /checks/red/.
+const checksDir = "checks"
+
+func splitPath(p string) []string {
+	p = strings.Trim(path.Clean("/"+p), "/")
+	if p == "" || p == "." {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+type dirStat struct {
+	name string
+}
+
+func (d dirStat) Name() string       { return d.name }
+func (d dirStat) Size() int64        { return 0 }
+func (d dirStat) Mode() os.FileMode  { return os.ModeDir | 0555 }
+func (d dirStat) ModTime() time.Time { return time.Time{} }
+func (d dirStat) IsDir() bool        { return true }
+func (d dirStat) Sys() interface{}   { return nil }
+
+type fileStat struct {
+	name string
+	size int64
+}
+
+func (f fileStat) Name() string       { return f.name }
+func (f fileStat) Size() int64        { return f.size }
+func (f fileStat) Mode() os.FileMode  { return 0644 }
+func (f fileStat) ModTime() time.Time { return time.Time{} }
+func (f fileStat) IsDir() bool        { return false }
+func (f fileStat) Sys() interface{}   { return nil }
+
+func dirInfo(name string) os.FileInfo        { return dirStat{name: name} }
+func fileInfo(name string, size int) os.FileInfo { return fileStat{name: name, size: int64(size)} }
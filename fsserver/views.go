@@ -0,0 +1,74 @@
+package fsserver
+
+import (
+	"io.bytenix.com/jiracsv/analysis"
+	"io.bytenix.com/jiracsv/jira"
+)
+
+// ChecksView wraps an IssueWalker and flattens it into a single synthetic
+// component grouping every issue whose CheckResult.Status matches want,
+// e.g. mounted at /checks/red/ to list everything currently RED regardless
+// of which real component it belongs to.
+type ChecksView struct {
+	Walker IssueWalker
+	Server *Server
+	Want   analysis.CheckResultStatus
+}
+
+// Components always reports the single synthetic grouping; ChecksView has
+// no real component boundaries of its own.
+func (v *ChecksView) Components() []string {
+	return []string{v.Want.String()}
+}
+
+// Issues ignores component and returns every issue across the wrapped
+// walker whose current CheckResult.Status equals Want.
+func (v *ChecksView) Issues(_ *string) []*jira.Issue {
+	var matched []*jira.Issue
+
+	for _, c := range v.Walker.Components() {
+		component := c
+
+		var analysisComponent *string
+		if component != unassignedDir {
+			analysisComponent = &component
+		}
+
+		for _, i := range v.Walker.Issues(&component) {
+			_, r := v.Server.analysisFor(analysisComponent, i)
+			if r.Status == v.Want {
+				matched = append(matched, i)
+			}
+		}
+	}
+
+	return matched
+}
+
+// find returns the single issue keyed key that's currently flagged Want,
+// together with the real component name it was analyzed under (or
+// unassignedDir for an orphan), or (nil, "") if key doesn't match.
+func (v *ChecksView) find(key string) (*jira.Issue, string) {
+	for _, c := range v.Walker.Components() {
+		component := c
+
+		var analysisComponent *string
+		if component != unassignedDir {
+			analysisComponent = &component
+		}
+
+		for _, i := range v.Walker.Issues(&component) {
+			if i.Key != key {
+				continue
+			}
+
+			if _, r := v.Server.analysisFor(analysisComponent, i); r.Status == v.Want {
+				return i, component
+			}
+
+			return nil, ""
+		}
+	}
+
+	return nil, ""
+}
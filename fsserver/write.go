@@ -0,0 +1,72 @@
+package fsserver
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"io.bytenix.com/jiracsv/jira"
+)
+
+// issueFile is the in-memory handle returned for an open issue file. Reads
+// are served from the snapshot taken at open time; on Close, if the file was
+// written to, the new value is pushed to Jira and the issue's cache entry is
+// dropped. The in-memory jira.Issue is not updated, so later reads of the
+// file still show the value it had before the write.
+type issueFile struct {
+	srv       *Server
+	component string
+	issue     *jira.Issue
+	name      string
+
+	content []byte
+	buf     bytes.Buffer
+	written bool
+}
+
+func (f *issueFile) Read(p []byte) (int, error) {
+	if len(f.content) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, f.content)
+	f.content = f.content[n:]
+	return n, nil
+}
+
+func (f *issueFile) Write(p []byte) (int, error) {
+	if !writableFiles[f.name] {
+		return 0, fmt.Errorf("fsserver: %s is read-only", f.name)
+	}
+
+	f.written = true
+	return f.buf.Write(p)
+}
+
+func (f *issueFile) Close() error {
+	if !f.written {
+		return nil
+	}
+
+	value := bytes.TrimRight(f.buf.Bytes(), "\n")
+
+	var err error
+	switch f.name {
+	case "owner":
+		err = f.srv.Client.SetOwner(f.issue.Key, string(value))
+	case "qa-contact":
+		err = f.srv.Client.SetQAContact(f.issue.Key, string(value))
+	case "description":
+		err = f.srv.Client.SetDescription(f.issue.Key, string(value))
+	case "acceptance":
+		err = f.srv.Client.SetAcceptance(f.issue.Key, string(value))
+	case "comment":
+		err = f.srv.Client.AddComment(f.issue.Key, string(value))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	f.srv.cache.invalidate(f.issue.Key)
+	return nil
+}
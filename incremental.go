@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"io.bytenix.com/jiracsv/analysis"
+	"io.bytenix.com/jiracsv/jira"
+	"io.bytenix.com/jiracsv/store"
+)
+
+// defaultStorePath returns the snapshot store path for a profile when
+// -store isn't given explicitly: ~/.jiracsv/<profile>.db.
+func defaultStorePath(profile string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(home, ".jiracsv")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, profile+".db"), nil
+}
+
+// resolveSince turns the -since flag value into a timestamp: "last" reads
+// the store's last completed run, anything else is parsed as RFC3339. An
+// empty value, or "last" on a store that has never completed a run, both
+// resolve to the zero time, meaning "fetch everything".
+func resolveSince(since string, st *store.Store) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+
+	if since == "last" {
+		return st.LastRun()
+	}
+
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("-since: %w", err)
+	}
+
+	return t, nil
+}
+
+// matchComponent returns the profile.Components.Include entry issue
+// belongs to, mirroring how ComponentsCollection.AddIssues buckets issues
+// into components, or nil if issue matches none of them (an orphan).
+func matchComponent(profile *Profile, issue *jira.Issue) *string {
+	for _, c := range profile.Components.Include {
+		if issue.HasComponent(c) {
+			name := c
+			return &name
+		}
+	}
+
+	return nil
+}
+
+// fetchIncremental resolves which issues to analyze for this run. When
+// -since resolves to the zero time (first run, or an explicit full fetch)
+// every issue matching profile.JQL is fetched, same as the non-incremental
+// path. Otherwise only issues whose `updated` field changed are re-fetched;
+// the rest are served from their last snapshot, reusing the jira.Issue
+// already persisted rather than calling Jira again for unchanged epics.
+//
+// Each changed issue is analyzed with the same component assignment the
+// render path would give it (via matchComponent) and the same registry
+// (checks.disable/checks.severity_override applied), so the CheckResult
+// snapshotted for diffing matches what's actually rendered for that issue.
+func fetchIncremental(profile *Profile, registry *analysis.CheckRegistry, jiraClient *jira.Client) ([]*jira.Issue, []*store.Transition, *store.Store, error) {
+	storePath := commandFlags.StorePath
+	if storePath == "" {
+		var err error
+		storePath, err = defaultStorePath(commandFlags.Profile)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	st, err := store.Open(storePath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	since, err := resolveSince(commandFlags.Since, st)
+	if err != nil {
+		st.Close()
+		return nil, nil, nil, err
+	}
+
+	jql := profile.JQL
+	if !since.IsZero() {
+		jql = fmt.Sprintf(`(%s) AND updated > "%s"`, profile.JQL, since.UTC().Format("2006-01-02 15:04"))
+	}
+
+	log.Printf("JQL = %s\n", jql)
+
+	changed, err := jiraClient.FindEpics(jql)
+	if err != nil {
+		st.Close()
+		return nil, nil, nil, err
+	}
+
+	log.Printf("JQL returned issues: %d changed since %s", len(changed), since)
+
+	seen := make(map[string]bool, len(changed))
+	issues := make([]*jira.Issue, 0, len(changed))
+	var transitions []*store.Transition
+
+	for _, issue := range changed {
+		seen[issue.Key] = true
+		issues = append(issues, issue)
+
+		old, err := st.Get(issue.Key)
+		if err != nil {
+			st.Close()
+			return nil, nil, nil, err
+		}
+
+		a := analysis.NewIssueAnalysis(issue, matchComponent(profile, issue))
+		current := &store.Snapshot{
+			Key:      issue.Key,
+			Updated:  issue.Fields.Updated,
+			Issue:    issue,
+			Analysis: a,
+			Check:    registry.Evaluate(a),
+		}
+
+		if t := store.Diff(old, current); t != nil {
+			transitions = append(transitions, t)
+		}
+
+		if err := st.Put(current); err != nil {
+			st.Close()
+			return nil, nil, nil, err
+		}
+	}
+
+	cached, err := st.All()
+	if err != nil {
+		st.Close()
+		return nil, nil, nil, err
+	}
+
+	for _, snap := range cached {
+		if seen[snap.Key] {
+			continue
+		}
+
+		if since.IsZero() {
+			// changed is the complete result set for profile.JQL on a full
+			// fetch, so anything else still in the store has fallen out of
+			// scope (e.g. a closed epic aged out of an "open" filter).
+			// Prune it rather than carrying it forward forever.
+			if err := st.Delete(snap.Key); err != nil {
+				st.Close()
+				return nil, nil, nil, err
+			}
+
+			continue
+		}
+
+		issues = append(issues, snap.Issue)
+	}
+
+	return issues, transitions, st, nil
+}
+
+// printTransitions renders the -diff-only "what changed" report: one line
+// per issue whose CheckResult.Status or Messages changed since its last
+// snapshot.
+func printTransitions(transitions []*store.Transition) {
+	for _, t := range transitions {
+		added := ""
+		if len(t.MessagesAdded) > 0 {
+			added = " +" + strings.Join(t.MessagesAdded, ",+")
+		}
+
+		removed := ""
+		if len(t.MessagesRemoved) > 0 {
+			removed = " -" + strings.Join(t.MessagesRemoved, ",-")
+		}
+
+		fmt.Printf("%s: %s -> %s%s%s\n", t.Key, t.From, t.To, added, removed)
+	}
+}
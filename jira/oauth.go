@@ -0,0 +1,163 @@
+package jira
+
+import (
+	"bufio"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrjones/oauth"
+)
+
+// tokenStorePath returns ~/.jiracsv/token.json, creating the containing
+// directory if necessary. Cached access tokens live here so that an
+// oauth-authenticated run only needs the interactive authorize/verify step
+// once.
+func tokenStorePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(home, ".jiracsv")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "token.json"), nil
+}
+
+// storedToken is the on-disk representation of an OAuth 1.0a access token.
+type storedToken struct {
+	Token  string `json:"token"`
+	Secret string `json:"secret"`
+}
+
+func loadStoredToken(path string) (*oauth.AccessToken, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var t storedToken
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+
+	return &oauth.AccessToken{Token: t.Token, Secret: t.Secret}, nil
+}
+
+func saveStoredToken(path string, tok *oauth.AccessToken) error {
+	data, err := json.Marshal(storedToken{Token: tok.Token, Secret: tok.Secret})
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// NewOAuthClient returns a Client authenticated against instanceURL via
+// OAuth 1.0a, signing requests with the RSA private key at privateKeyPath
+// against consumerKey. consumerKey must be registered on a JIRA Application
+// Link configured with the matching public key.
+//
+// On first use it prints the authorization URL to stdout and reads the
+// verifier Jira displays back from stdin, then caches the resulting access
+// token in ~/.jiracsv/token.json so later runs are non-interactive.
+func NewOAuthClient(instanceURL, consumerKey, privateKeyPath string) (*Client, error) {
+	privateKey, err := readRSAPrivateKey(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	consumer := oauth.NewRSAConsumer(consumerKey, privateKey, oauth.ServiceProvider{
+		RequestTokenUrl:   instanceURL + "/plugins/servlet/oauth/request-token",
+		AuthorizeTokenUrl: instanceURL + "/plugins/servlet/oauth/authorize",
+		AccessTokenUrl:    instanceURL + "/plugins/servlet/oauth/access-token",
+	})
+
+	path, err := tokenStorePath()
+	if err != nil {
+		return nil, fmt.Errorf("jira: locating token store: %w", err)
+	}
+
+	accessToken, err := loadStoredToken(path)
+	if err != nil {
+		accessToken, err = authorizeOAuth(consumer)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := saveStoredToken(path, accessToken); err != nil {
+			return nil, fmt.Errorf("jira: saving access token: %w", err)
+		}
+	}
+
+	httpClient, err := consumer.MakeHttpClient(accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("jira: building OAuth http client: %w", err)
+	}
+
+	return newClientWithHTTP(instanceURL, httpClient)
+}
+
+// readRSAPrivateKey loads and parses the PEM-encoded RSA private key at
+// path, accepting either PKCS#1 ("BEGIN RSA PRIVATE KEY") or PKCS#8
+// ("BEGIN PRIVATE KEY") encoding.
+func readRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jira: reading private key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jira: %s does not contain a PEM block", path)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("jira: parsing private key: %w", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("jira: %s is not an RSA private key", path)
+	}
+
+	return rsaKey, nil
+}
+
+// authorizeOAuth drives the interactive request-token/authorize/verifier
+// exchange, used whenever no access token can be loaded from the token store.
+func authorizeOAuth(consumer *oauth.Consumer) (*oauth.AccessToken, error) {
+	requestToken, authURL, err := consumer.GetRequestTokenAndUrl("oob")
+	if err != nil {
+		return nil, fmt.Errorf("jira: getting request token: %w", err)
+	}
+
+	fmt.Printf("Authorize jiracsv at: %s\n", authURL)
+	fmt.Print("Verification code: ")
+
+	verifier, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("jira: reading verifier: %w", err)
+	}
+
+	accessToken, err := consumer.AuthorizeToken(requestToken, strings.TrimSpace(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("jira: authorizing token: %w", err)
+	}
+
+	return accessToken, nil
+}
@@ -6,27 +6,129 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"io.bytenix.com/jiracsv/analysis"
 	"io.bytenix.com/jiracsv/jira"
+	"io.bytenix.com/jiracsv/output"
+	"io.bytenix.com/jiracsv/store"
 )
 
 var commandFlags = struct {
 	Configuration string
 	Profile       string
 	Username      string
+	Fs            bool
+	FsAddr        string
+	FsCacheTTL    time.Duration
+	OAuth         bool
+	ConsumerKey   string
+	PrivateKey    string
+	ListChecks    bool
+	Format        string
+	Since         string
+	DiffOnly      bool
+	StorePath     string
 }{}
 
 func init() {
 	flag.StringVar(&commandFlags.Username, "u", "", "Jira username")
 	flag.StringVar(&commandFlags.Configuration, "c", "", "Configuration file")
 	flag.StringVar(&commandFlags.Profile, "p", "", "Search profile")
+	flag.BoolVar(&commandFlags.Fs, "fs", false, "Serve the profile as a 9P filesystem instead of writing CSV")
+	flag.StringVar(&commandFlags.FsAddr, "fs-addr", "tcp!0.0.0.0!5640", "Address to listen on in -fs mode")
+	flag.DurationVar(&commandFlags.FsCacheTTL, "fs-cache-ttl", time.Minute, "How long to reuse analyzed issues before re-analyzing them in -fs mode")
+	flag.BoolVar(&commandFlags.OAuth, "oauth", false, "Authenticate via OAuth 1.0a instead of username/password")
+	flag.StringVar(&commandFlags.ConsumerKey, "ckey", "", "OAuth consumer key registered on the Jira Application Link")
+	flag.StringVar(&commandFlags.PrivateKey, "pkey", "", "Path to the OAuth consumer's RSA private key (PEM)")
+	flag.BoolVar(&commandFlags.ListChecks, "list-checks", false, "Print the registered checks and exit")
+	flag.StringVar(&commandFlags.Format, "format", "tsv", "Output format: tsv, json, markdown, tty")
+	flag.StringVar(&commandFlags.Since, "since", "", `Only re-fetch issues updated after this time ("last" or RFC3339); reuses the snapshot store for the rest`)
+	flag.BoolVar(&commandFlags.DiffOnly, "diff-only", false, "With -since, print only a what-changed report instead of the full analysis")
+	flag.StringVar(&commandFlags.StorePath, "store", "", "Snapshot store path (default ~/.jiracsv/<profile>.db)")
 }
 
-func writeIssues(w *csv.Writer, component *string, issues []*jira.Issue) {
+// renderIssues writes componentIssues through r, honoring the profile's
+// component exclude list the same way the original TSV loop did.
+func renderIssues(r output.Renderer, registry *analysis.CheckRegistry, profile *Profile, componentIssues *ComponentsCollection) error {
+	for _, k := range componentIssues.Items {
+		excluded := false
+
+		for _, c := range profile.Components.Exclude {
+			if k.Name == c {
+				excluded = true
+				break
+			}
+		}
+
+		if excluded {
+			continue
+		}
+
+		name := k.Name
+		if err := r.RenderComponent(&name); err != nil {
+			return err
+		}
+
+		for _, i := range k.Issues {
+			a := analysis.NewIssueAnalysis(i, &name)
+			if err := r.RenderIssue(i, a, registry.Evaluate(a)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := r.RenderComponent(nil); err != nil {
+		return err
+	}
+
+	for _, i := range componentIssues.Orphans {
+		a := analysis.NewIssueAnalysis(i, nil)
+		if err := r.RenderIssue(i, a, registry.Evaluate(a)); err != nil {
+			return err
+		}
+	}
+
+	return r.Flush()
+}
+
+// listChecks prints the DefaultRegistry's checks, one per line, for the
+// -list-checks mode.
+func listChecks() {
+	for _, c := range analysis.DefaultRegistry.List() {
+		fmt.Printf("%-20s %-7s %s\n", c.ID, c.Severity, c.Description)
+	}
+}
+
+// profileCheckRegistry derives a CheckRegistry from the DefaultRegistry,
+// applying profile's checks.disable, checks.severity_override and
+// checks.custom config. It always returns a registry private to profile,
+// never DefaultRegistry itself, so registering profile's custom checks
+// can't leak into another profile's analysis.
+func profileCheckRegistry(profile *Profile) *analysis.CheckRegistry {
+	registry := analysis.DefaultRegistry.Disable(profile.Checks.Disable...)
+
+	for id, severity := range profile.Checks.SeverityOverride {
+		parsed, err := analysis.ParseCheckSeverity(severity)
+
+		if err != nil {
+			panic(err)
+		}
+
+		registry = registry.SeverityOverride(id, parsed)
+	}
+
+	if err := analysis.RegisterCustomChecks(registry, profile.Checks.Custom); err != nil {
+		panic(err)
+	}
+
+	return registry
+}
+
+func writeIssues(w *csv.Writer, registry *analysis.CheckRegistry, component *string, issues []*jira.Issue) {
 	for _, i := range issues {
 		a := analysis.NewIssueAnalysis(i, component)
-		r := analysis.NewCheckResult(a)
+		r := registry.Evaluate(a)
 
 		w.Write([]string{
 			googleSheetLink(i.Link, i.Key),
@@ -49,6 +151,11 @@ func writeIssues(w *csv.Writer, component *string, issues []*jira.Issue) {
 func main() {
 	flag.Parse()
 
+	if commandFlags.ListChecks {
+		listChecks()
+		return
+	}
+
 	if commandFlags.Configuration == "" {
 		panic(fmt.Errorf("configuration file not specified"))
 	}
@@ -69,8 +176,18 @@ func main() {
 		panic(fmt.Errorf("profile '%s' not found", commandFlags.Profile))
 	}
 
-	password := GetPassword("PASSWORD", true)
-	jiraClient, err := jira.NewClient(config.Instance.URL, &commandFlags.Username, &password)
+	var jiraClient *jira.Client
+
+	if commandFlags.OAuth {
+		if commandFlags.ConsumerKey == "" || commandFlags.PrivateKey == "" {
+			panic(fmt.Errorf("-oauth requires -ckey and -pkey"))
+		}
+
+		jiraClient, err = jira.NewOAuthClient(config.Instance.URL, commandFlags.ConsumerKey, commandFlags.PrivateKey)
+	} else {
+		password := GetPassword("PASSWORD", true)
+		jiraClient, err = jira.NewClient(config.Instance.URL, &commandFlags.Username, &password)
+	}
 
 	if err != nil {
 		panic(err)
@@ -79,24 +196,78 @@ func main() {
 	w := csv.NewWriter(os.Stdout)
 	w.Comma = '\t'
 
+	registry := profileCheckRegistry(profile)
+
 	componentIssues := NewComponentsCollection()
 
 	for _, c := range profile.Components.Include {
 		componentIssues.Add(c)
 	}
 
-	log.Printf("JQL = %s\n", profile.JQL)
+	var issues []*jira.Issue
+	var incrementalStore *store.Store
+	var transitions []*store.Transition
 
-	issues, err := jiraClient.FindEpics(profile.JQL)
+	if commandFlags.Since != "" {
+		issues, transitions, incrementalStore, err = fetchIncremental(profile, registry, jiraClient)
 
-	if err != nil {
-		panic(err)
+		if err != nil {
+			panic(err)
+		}
+
+		defer incrementalStore.Close()
+	} else {
+		log.Printf("JQL = %s\n", profile.JQL)
+
+		issues, err = jiraClient.FindEpics(profile.JQL)
+
+		if err != nil {
+			panic(err)
+		}
+
+		log.Printf("JQL returned issues: %d", len(issues))
 	}
 
-	log.Printf("JQL returned issues: %d", len(issues))
+	if commandFlags.DiffOnly {
+		printTransitions(transitions)
+
+		if incrementalStore != nil {
+			if err := incrementalStore.SetLastRun(time.Now()); err != nil {
+				panic(err)
+			}
+		}
+
+		return
+	}
 
 	componentIssues.AddIssues(issues)
 
+	if incrementalStore != nil {
+		if err := incrementalStore.SetLastRun(time.Now()); err != nil {
+			panic(err)
+		}
+	}
+
+	if commandFlags.Fs {
+		if err := fsserve(commandFlags.Profile, componentIssues, jiraClient, registry); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	if commandFlags.Format != "" && commandFlags.Format != "tsv" {
+		r, err := output.New(commandFlags.Format, os.Stdout)
+		if err != nil {
+			panic(err)
+		}
+
+		if err := renderIssues(r, registry, profile, componentIssues); err != nil {
+			panic(err)
+		}
+
+		return
+	}
+
 	for _, k := range componentIssues.Items {
 		skipComponent := false
 
@@ -112,13 +283,13 @@ func main() {
 		}
 
 		w.Write(append([]string{k.Name}, make([]string, 12)...))
-		writeIssues(w, &k.Name, k.Issues)
+		writeIssues(w, registry, &k.Name, k.Issues)
 
 		w.Flush()
 	}
 
 	w.Write([]string{"[UNASSIGNED]"})
-	writeIssues(w, nil, componentIssues.Orphans)
+	writeIssues(w, registry, nil, componentIssues.Orphans)
 
 	w.Flush()
 }
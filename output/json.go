@@ -0,0 +1,87 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"io.bytenix.com/jiracsv/analysis"
+	"io.bytenix.com/jiracsv/jira"
+)
+
+// issueRecord is the JSON shape of a single rendered issue, including the
+// full CheckResult so downstream consumers don't need to re-run analysis.
+type issueRecord struct {
+	Component        *string               `json:"component"`
+	Key              string                `json:"key"`
+	Link             string                `json:"link"`
+	Summary          string                `json:"summary"`
+	Type             string                `json:"type"`
+	Priority         string                `json:"priority"`
+	Status           string                `json:"status"`
+	Owner            string                `json:"owner"`
+	QAContact        string                `json:"qaContact"`
+	IssuesCompletion string                `json:"issuesCompletion"`
+	PointsCompletion string                `json:"pointsCompletion"`
+	Check            *analysis.CheckResult `json:"check"`
+}
+
+// jsonRenderer streams issueRecords out as a single JSON array, one element
+// written (and the stream flushed) per RenderIssue call.
+type jsonRenderer struct {
+	enc       *json.Encoder
+	w         io.Writer
+	component *string
+	n         int
+}
+
+func newJSONRenderer(w io.Writer) *jsonRenderer {
+	return &jsonRenderer{enc: json.NewEncoder(w), w: w}
+}
+
+func (j *jsonRenderer) RenderComponent(component *string) error {
+	j.component = component
+	return nil
+}
+
+func (j *jsonRenderer) RenderIssue(issue *jira.Issue, a *analysis.IssueAnalysis, r *analysis.CheckResult) error {
+	prefix := ",\n"
+	if j.n == 0 {
+		prefix = "[\n"
+	}
+
+	if _, err := io.WriteString(j.w, prefix); err != nil {
+		return err
+	}
+
+	j.n++
+
+	record := issueRecord{
+		Component:        j.component,
+		Key:              issue.Key,
+		Link:             issue.Link,
+		Summary:          issue.Fields.Summary,
+		Type:             issue.Fields.Type.Name,
+		Priority:         issue.Fields.Priority.Name,
+		Status:           issue.Fields.Status.Name,
+		Owner:            issue.Owner,
+		QAContact:        issue.QAContact,
+		IssuesCompletion: fmt.Sprintf("%d/%d", a.IssuesCompletion.Status, a.IssuesCompletion.Total),
+		PointsCompletion: fmt.Sprintf("%d/%d", a.PointsCompletion.Status, a.PointsCompletion.Total),
+		Check:            r,
+	}
+
+	return j.enc.Encode(record)
+}
+
+// Flush closes the JSON array. It must be called exactly once, after the
+// last RenderIssue call.
+func (j *jsonRenderer) Flush() error {
+	if j.n == 0 {
+		_, err := io.WriteString(j.w, "[]\n")
+		return err
+	}
+
+	_, err := io.WriteString(j.w, "]\n")
+	return err
+}
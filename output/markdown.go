@@ -0,0 +1,73 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"io.bytenix.com/jiracsv/analysis"
+	"io.bytenix.com/jiracsv/jira"
+)
+
+var markdownHeader = []string{"Key", "Summary", "Type", "Priority", "Status", "Owner", "QA Contact", "Ready", "Check"}
+
+// markdownRenderer writes one GFM table per component, suitable for pasting
+// into a PR description or chat message.
+type markdownRenderer struct {
+	w       io.Writer
+	started bool
+}
+
+func newMarkdownRenderer(w io.Writer) *markdownRenderer {
+	return &markdownRenderer{w: w}
+}
+
+func (m *markdownRenderer) RenderComponent(component *string) error {
+	name := "[UNASSIGNED]"
+	if component != nil {
+		name = *component
+	}
+
+	prefix := ""
+	if m.started {
+		prefix = "\n"
+	}
+	m.started = true
+
+	_, err := fmt.Fprintf(m.w, "%s## %s\n\n| %s |\n|%s|\n",
+		prefix, name, strings.Join(markdownHeader, " | "), strings.Repeat(" --- |", len(markdownHeader)))
+
+	return err
+}
+
+func (m *markdownRenderer) RenderIssue(issue *jira.Issue, a *analysis.IssueAnalysis, r *analysis.CheckResult) error {
+	row := []string{
+		tableCell(fmt.Sprintf("[%s](%s)", issue.Key, issue.Link)),
+		tableCell(issue.Fields.Summary),
+		tableCell(issue.Fields.Type.Name),
+		tableCell(issue.Fields.Priority.Name),
+		tableCell(issue.Fields.Status.Name),
+		tableCell(issue.Owner),
+		tableCell(issue.QAContact),
+		strconv.FormatBool(r.Ready),
+		tableCell(fmt.Sprintf("%s (%s)", r.Status, strings.Join(r.Messages, ", "))),
+	}
+
+	_, err := fmt.Fprintf(m.w, "| %s |\n", strings.Join(row, " | "))
+	return err
+}
+
+func (m *markdownRenderer) Flush() error {
+	return nil
+}
+
+// tableCell escapes a value for use as a single GFM table cell: "|" would
+// otherwise split the cell, and a literal newline would break the row onto
+// its own (invalid) table line.
+func tableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
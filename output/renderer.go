@@ -0,0 +1,51 @@
+// Package output renders analyzed Jira issues in a chosen alternative
+// format, so the same analysis pipeline that used to only write TSV for
+// spreadsheet import can also feed a CI dashboard, a chat-bot post, or a
+// terminal review session.
+//
+// The original tab-separated spreadsheet output is not one of these
+// Renderers: it embeds Google Sheets formulas (HYPERLINK, checkbox
+// ballots, ...) that only main.go's writeIssues knows how to build, and
+// stays the CLI's hardcoded default when -format is empty or "tsv".
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"io.bytenix.com/jiracsv/analysis"
+	"io.bytenix.com/jiracsv/jira"
+)
+
+// Renderer writes analyzed issues to an underlying stream, grouped by
+// component in the order RenderComponent/RenderIssue are called.
+type Renderer interface {
+	// RenderComponent starts a new component grouping. component is nil
+	// for the synthetic "[UNASSIGNED]" grouping.
+	RenderComponent(component *string) error
+
+	// RenderIssue writes a single analyzed issue under the most recently
+	// started component.
+	RenderIssue(issue *jira.Issue, a *analysis.IssueAnalysis, r *analysis.CheckResult) error
+
+	// Flush writes any buffered output and, for formats that wrap the
+	// whole stream (e.g. a JSON array), closes it. Callers call it
+	// exactly once, after the last RenderIssue call.
+	Flush() error
+}
+
+// New returns the Renderer registered for format, writing to w. format
+// must be one of the alternative formats below; the CLI never calls New
+// for "" or "tsv", since those stay its hardcoded spreadsheet output.
+func New(format string, w io.Writer) (Renderer, error) {
+	switch format {
+	case "json":
+		return newJSONRenderer(w), nil
+	case "markdown", "md":
+		return newMarkdownRenderer(w), nil
+	case "tty":
+		return newTTYRenderer(w), nil
+	default:
+		return nil, fmt.Errorf("output: unknown format %q", format)
+	}
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+
+	"io.bytenix.com/jiracsv/analysis"
+	"io.bytenix.com/jiracsv/jira"
+)
+
+// ttyRenderer prints an aligned, colorized table for interactive review:
+// check status in its matching GREEN/YELLOW/RED color, the ready ballot in
+// bold. When w isn't an attached terminal it falls back to plain text, so
+// piping to a file or another program doesn't leave escape codes behind.
+type ttyRenderer struct {
+	w     *tabwriter.Writer
+	color bool
+}
+
+func newTTYRenderer(w io.Writer) *ttyRenderer {
+	isTTY := false
+	if f, ok := w.(*os.File); ok {
+		isTTY = term.IsTerminal(int(f.Fd()))
+	}
+
+	return &ttyRenderer{
+		w:     tabwriter.NewWriter(w, 0, 2, 2, ' ', 0),
+		color: isTTY && !color.NoColor,
+	}
+}
+
+func (t *ttyRenderer) RenderComponent(component *string) error {
+	name := "[UNASSIGNED]"
+	if component != nil {
+		name = *component
+	}
+
+	_, err := fmt.Fprintf(t.w, "\n%s\n", name)
+	return err
+}
+
+func (t *ttyRenderer) RenderIssue(issue *jira.Issue, a *analysis.IssueAnalysis, r *analysis.CheckResult) error {
+	ready := "NO"
+	if r.Ready {
+		ready = "YES"
+	}
+
+	if t.color {
+		ready = color.New(color.Bold).Sprint(ready)
+	}
+
+	status := r.Status.String()
+	if t.color {
+		status = statusColor(r.Status).Sprint(status)
+	}
+
+	_, err := fmt.Fprintf(t.w, "%s\t%s\t%s\t%s\t%s\t%s\t%v\n",
+		issue.Key, issue.Fields.Summary, issue.Owner, ready, status, issue.Fields.Status.Name, r.Messages)
+
+	return err
+}
+
+func (t *ttyRenderer) Flush() error {
+	return t.w.Flush()
+}
+
+func statusColor(status analysis.CheckResultStatus) *color.Color {
+	switch status {
+	case analysis.CheckStatusGreen:
+		return color.New(color.FgGreen)
+	case analysis.CheckStatusYellow:
+		return color.New(color.FgYellow)
+	case analysis.CheckStatusRed:
+		return color.New(color.FgRed)
+	default:
+		return color.New(color.Reset)
+	}
+}
@@ -0,0 +1,63 @@
+package store
+
+import "io.bytenix.com/jiracsv/analysis"
+
+// Transition describes how an issue's CheckResult changed between two
+// snapshots.
+type Transition struct {
+	Key             string
+	From            analysis.CheckResultStatus
+	To              analysis.CheckResultStatus
+	MessagesAdded   []string
+	MessagesRemoved []string
+}
+
+// Diff compares old (may be nil, for an issue seen for the first time)
+// against current and returns the Transition between them, or nil if
+// neither the status nor the message set changed.
+func Diff(old, current *Snapshot) *Transition {
+	if current == nil {
+		return nil
+	}
+
+	if old == nil {
+		return &Transition{
+			Key:           current.Key,
+			From:          analysis.CheckStatusNone,
+			To:            current.Check.Status,
+			MessagesAdded: current.Check.Messages,
+		}
+	}
+
+	added := messageSetDiff(current.Check.Messages, old.Check.Messages)
+	removed := messageSetDiff(old.Check.Messages, current.Check.Messages)
+
+	if old.Check.Status == current.Check.Status && len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	return &Transition{
+		Key:             current.Key,
+		From:            old.Check.Status,
+		To:              current.Check.Status,
+		MessagesAdded:   added,
+		MessagesRemoved: removed,
+	}
+}
+
+// messageSetDiff returns the elements of a that are not present in b.
+func messageSetDiff(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, m := range b {
+		in[m] = true
+	}
+
+	var diff []string
+	for _, m := range a {
+		if !in[m] {
+			diff = append(diff, m)
+		}
+	}
+
+	return diff
+}
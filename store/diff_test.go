@@ -0,0 +1,102 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"io.bytenix.com/jiracsv/analysis"
+)
+
+func snapshot(status analysis.CheckResultStatus, messages ...string) *Snapshot {
+	return &Snapshot{
+		Key:   "KEY-1",
+		Check: &analysis.CheckResult{Status: status, Messages: messages},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		old  *Snapshot
+		cur  *Snapshot
+		want *Transition
+	}{
+		{
+			name: "current nil",
+			old:  snapshot(analysis.CheckStatusGreen),
+			cur:  nil,
+			want: nil,
+		},
+		{
+			name: "first time seen",
+			old:  nil,
+			cur:  snapshot(analysis.CheckStatusYellow, "NOVERSION"),
+			want: &Transition{
+				Key:           "KEY-1",
+				From:          analysis.CheckStatusNone,
+				To:            analysis.CheckStatusYellow,
+				MessagesAdded: []string{"NOVERSION"},
+			},
+		},
+		{
+			name: "unchanged",
+			old:  snapshot(analysis.CheckStatusGreen, "ALONGSIDE"),
+			cur:  snapshot(analysis.CheckStatusGreen, "ALONGSIDE"),
+			want: nil,
+		},
+		{
+			name: "status changed, messages unchanged",
+			old:  snapshot(analysis.CheckStatusYellow, "ALONGSIDE"),
+			cur:  snapshot(analysis.CheckStatusRed, "ALONGSIDE"),
+			want: &Transition{
+				Key:  "KEY-1",
+				From: analysis.CheckStatusYellow,
+				To:   analysis.CheckStatusRed,
+			},
+		},
+		{
+			name: "messages added and removed, status unchanged",
+			old:  snapshot(analysis.CheckStatusYellow, "NOVERSION", "NODESCRIPTION"),
+			cur:  snapshot(analysis.CheckStatusYellow, "NODESCRIPTION", "NOACKS"),
+			want: &Transition{
+				Key:             "KEY-1",
+				From:            analysis.CheckStatusYellow,
+				To:              analysis.CheckStatusYellow,
+				MessagesAdded:   []string{"NOACKS"},
+				MessagesRemoved: []string{"NOVERSION"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.old, tt.cur)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageSetDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want []string
+	}{
+		{name: "no overlap", a: []string{"X", "Y"}, b: nil, want: []string{"X", "Y"}},
+		{name: "full overlap", a: []string{"X", "Y"}, b: []string{"X", "Y"}, want: nil},
+		{name: "partial overlap", a: []string{"X", "Y", "Z"}, b: []string{"Y"}, want: []string{"X", "Z"}},
+		{name: "empty a", a: nil, b: []string{"X"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := messageSetDiff(tt.a, tt.b)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messageSetDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
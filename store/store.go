@@ -0,0 +1,169 @@
+// Package store persists per-issue analysis snapshots in a local BoltDB
+// file, so that a large profile (thousands of epics) doesn't need a full
+// Jira re-fetch and re-analysis on every run. Only issues whose `updated`
+// field changed since the last run need to be re-fetched; everything else
+// is served from the snapshot taken last time.
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"io.bytenix.com/jiracsv/analysis"
+	"io.bytenix.com/jiracsv/jira"
+)
+
+var (
+	issuesBucket = []byte("issues")
+	metaBucket   = []byte("meta")
+
+	lastRunKey = []byte("last_run")
+)
+
+// Snapshot is the persisted state of a single issue as of one run: the
+// fetched Issue plus the analysis and check result computed from it.
+type Snapshot struct {
+	Key      string                  `json:"key"`
+	Updated  time.Time               `json:"updated"`
+	Issue    *jira.Issue             `json:"issue"`
+	Analysis *analysis.IssueAnalysis `json:"analysis"`
+	Check    *analysis.CheckResult   `json:"check"`
+}
+
+// Store is a BoltDB-backed snapshot cache for a single profile. It is safe
+// for concurrent use.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens (creating if necessary) the snapshot store at path.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("store: opening %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(issuesBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(metaBucket)
+		return err
+	})
+
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("store: initializing %s: %w", path, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Get returns the snapshot stored for key, or nil if none exists.
+func (s *Store) Get(key string) (*Snapshot, error) {
+	var snap *Snapshot
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(issuesBucket).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+
+		snap = &Snapshot{}
+		return json.Unmarshal(data, snap)
+	})
+
+	return snap, err
+}
+
+// Put persists snap, keyed by snap.Key, overwriting any prior snapshot.
+func (s *Store) Put(snap *Snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("store: encoding %s: %w", snap.Key, err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(issuesBucket).Put([]byte(snap.Key), data)
+	})
+}
+
+// Delete removes the snapshot for key, if any. Used to prune issues that
+// have fallen out of a profile's JQL scope (e.g. closed epics aged out of
+// an "open" filter), so All() doesn't grow without bound across runs.
+func (s *Store) Delete(key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(issuesBucket).Delete([]byte(key))
+	})
+}
+
+// Keys returns every issue key currently in the store.
+func (s *Store) Keys() ([]string, error) {
+	var keys []string
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(issuesBucket).ForEach(func(k, _ []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+	})
+
+	return keys, err
+}
+
+// All returns every snapshot currently in the store, in no particular
+// order. Used to reassemble a profile's full issue set between runs when
+// only the changed subset was re-fetched.
+func (s *Store) All() ([]*Snapshot, error) {
+	var snaps []*Snapshot
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(issuesBucket).ForEach(func(_, data []byte) error {
+			snap := &Snapshot{}
+			if err := json.Unmarshal(data, snap); err != nil {
+				return err
+			}
+			snaps = append(snaps, snap)
+			return nil
+		})
+	})
+
+	return snaps, err
+}
+
+// LastRun returns the time SetLastRun was last called with, or the zero
+// time if this store has never completed a run.
+func (s *Store) LastRun() (time.Time, error) {
+	var last time.Time
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(metaBucket).Get(lastRunKey)
+		if data == nil {
+			return nil
+		}
+
+		return last.UnmarshalText(data)
+	})
+
+	return last, err
+}
+
+// SetLastRun records t as the time of the most recently completed run.
+func (s *Store) SetLastRun(t time.Time) error {
+	data, err := t.MarshalText()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(metaBucket).Put(lastRunKey, data)
+	})
+}